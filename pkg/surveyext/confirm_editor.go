@@ -0,0 +1,84 @@
+package surveyext
+
+// This file adds a yes/no/edit tri-state prompt that composes with GhEditor,
+// following the same Render/NewRuneReader/template pattern established in
+// editor.go.
+
+import (
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/terminal"
+)
+
+// GhConfirmEditor shows a yes/no/edit tri-state: 'y' accepts the default
+// body, 'n' aborts, 'e' launches the editor by reusing GhEditor's
+// editAndValidate
+type GhConfirmEditor struct {
+	*GhEditor
+}
+
+// NewGhConfirmEditor wraps an existing GhEditor with the yes/no/edit prompt
+func NewGhConfirmEditor(editor *GhEditor) *GhConfirmEditor {
+	return &GhConfirmEditor{GhEditor: editor}
+}
+
+// ConfirmEditorQuestionTemplate is rendered before reading the y/n/e keypress
+var ConfirmEditorQuestionTemplate = `
+{{- if .ShowHelp }}{{- color .Config.Icons.Help.Format }}{{ .Config.Icons.Help.Text }} {{ .Help }}{{color "reset"}}{{"\n"}}{{end}}
+{{- color .Config.Icons.Question.Format }}{{ .Config.Icons.Question.Text }} {{color "reset"}}
+{{- color "default+hb"}}{{ .Message }} {{color "reset"}}
+{{- color "cyan"}}[(y) to accept, (n) to abort, (e) to edit in {{ .EditorName }}] {{color "reset"}}`
+
+// Prompt implements survey.Prompt
+func (c *GhConfirmEditor) Prompt(config *survey.PromptConfig) (interface{}, error) {
+	cmdString, err := c.resolveEditor()
+	if err != nil {
+		return "", err
+	}
+	editorName := editorDisplayName(cmdString)
+
+	err = c.Render(
+		ConfirmEditorQuestionTemplate,
+		EditorTemplateData{
+			Editor:     *c.Editor,
+			EditorName: editorName,
+			Config:     config,
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	rr := c.NewRuneReader()
+	rr.SetTermMode()
+	defer rr.RestoreTermMode()
+
+	for {
+		r, _, err := rr.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		switch r {
+		case 'y', 'Y', '\r', '\n':
+			// EXTENDED validate the default body too, for consistency with
+			// the 'e' path's editAndValidate
+			if c.Validate != nil {
+				if err := c.Validate(c.Default); err != nil {
+					return "", err
+				}
+			}
+			return c.Default, nil
+		case 'n', 'N', terminal.KeyInterrupt, terminal.KeyEndTransmission:
+			return "", terminal.InterruptErr
+		case 'e', 'E':
+			// EXTENDED jump straight to the editor: reuse GhEditor's
+			// editAndValidate rather than its prompt, which would otherwise
+			// block on its own separate "(e) to launch" keypress gate
+			initialValue := ""
+			if c.Default != "" && c.AppendDefault {
+				initialValue = c.Default
+			}
+			return c.editAndValidate(initialValue, cmdString)
+		}
+	}
+}
@@ -6,37 +6,189 @@ package surveyext
 
 import (
 	"bytes"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"text/template"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/AlecAivazis/survey/v2/terminal"
 	shellquote "github.com/kballard/go-shellquote"
+	"github.com/mattn/go-isatty"
 )
 
-var (
-	bom    = []byte{0xef, 0xbb, 0xbf}
-	editor = "nano" // EXTENDED to switch from vim as a default editor
-)
+var bom = []byte{0xef, 0xbb, 0xbf}
+
+// EXTENDED marks where a rendered MessageTemplate header ends, mirroring git's
+// scissor line convention so hint text above it never reaches the final answer.
+const defaultCutLine = "# ------------------------ >8 ------------------------"
+
+// EXTENDED resolves the editor command to launch. Resolution order is: an
+// explicit GhEditor.EditorCommand, LookupEditor (so callers can fold in e.g.
+// `git config core.editor`), GH_EDITOR, GIT_EDITOR, VISUAL, EDITOR, then a
+// platform default. Replaces the old package-level editor var + init(), which
+// could not be overridden per-repo/per-invocation and broke parallel tests.
+type EditorResolver struct {
+	// LookupEditor is a caller-supplied lookup consulted ahead of the
+	// environment variables, e.g. to read `git config core.editor`
+	LookupEditor func() (string, error)
+}
+
+// DefaultEditorResolver is used by GhEditor when Resolver is nil
+var DefaultEditorResolver = &EditorResolver{}
+
+func (r *EditorResolver) resolve(e *GhEditor) (string, error) {
+	if e.EditorCommand != "" {
+		return e.EditorCommand, nil
+	}
+
+	if r.LookupEditor != nil {
+		cmd, err := r.LookupEditor()
+		if err != nil {
+			return "", err
+		}
+		if cmd != "" {
+			return cmd, nil
+		}
+	}
+
+	for _, envVar := range []string{"GH_EDITOR", "GIT_EDITOR", "VISUAL", "EDITOR"} {
+		if cmd := os.Getenv(envVar); cmd != "" {
+			return cmd, nil
+		}
+	}
 
-func init() {
 	if runtime.GOOS == "windows" {
-		editor = "notepad"
-	} else if g := os.Getenv("GIT_EDITOR"); g != "" {
-		editor = g
-	} else if v := os.Getenv("VISUAL"); v != "" {
-		editor = v
-	} else if e := os.Getenv("EDITOR"); e != "" {
-		editor = e
+		return "notepad", nil
 	}
+	return "nano", nil // EXTENDED to switch from vim as a default editor
 }
 
 // EXTENDED to enable different prompting behavior
 type GhEditor struct {
 	*survey.Editor
+
+	// EXTENDED to validate the edited text, offering the user a chance to
+	// re-edit, accept anyway, or cancel when validation fails
+	Validate survey.Validator
+
+	// EXTENDED to render a leading comment block (e.g. diff or template hints)
+	// into the editor buffer; it is stripped from the value returned to the caller
+	MessageTemplate string
+	TemplateData    interface{}
+
+	// EXTENDED rune that marks a comment line for stripping; defaults to '#'
+	CommentChar rune
+
+	// EXTENDED line at or above which everything is discarded and below which
+	// the text is kept, the reverse of git's commit.cleanup=scissors (which
+	// drops what follows the line); defaults to defaultCutLine
+	ScissorLine string
+
+	// EXTENDED to pin the editor command, bypassing EditorResolver's lookup chain
+	EditorCommand string
+
+	// EXTENDED to override how the editor command is resolved; defaults to
+	// DefaultEditorResolver when nil
+	Resolver *EditorResolver
+
+	// EXTENDED controls what happens when stdin is not a terminal; defaults
+	// to NonInteractiveReadStdin
+	NonInteractiveMode NonInteractiveMode
+
+	// EXTENDED controls how the editor is launched under a Windows mintty/MSYS
+	// pty (e.g. Git Bash); defaults to WindowsPtyAuto
+	WindowsPtyStrategy WindowsPtyStrategy
+}
+
+// EXTENDED how the editor is launched on Windows when attached to a
+// mintty/MSYS pty, where os/exec can't drive a console editor directly
+type WindowsPtyStrategy int
+
+const (
+	// WindowsPtyAuto detects a mintty pty and picks Winpty if available,
+	// otherwise Detached; on a native console it behaves like Direct
+	WindowsPtyAuto WindowsPtyStrategy = iota
+	// WindowsPtyWinpty wraps the editor command with winpty
+	WindowsPtyWinpty
+	// WindowsPtyDetached starts the editor without our stdio attached and
+	// waits for the editor process to exit; the temp file's mtime is not polled
+	WindowsPtyDetached
+	// WindowsPtyDirect runs the editor via os/exec, as on other platforms
+	WindowsPtyDirect
+)
+
+// EXTENDED a Git Bash / MSYS mintty pty is the case os/exec can't drive
+func isMinttyPty() bool {
+	return os.Getenv("MSYSTEM") != "" || os.Getenv("TERM_PROGRAM") == "mintty"
+}
+
+func (e *GhEditor) windowsPtyStrategy() WindowsPtyStrategy {
+	if e.WindowsPtyStrategy != WindowsPtyAuto {
+		return e.WindowsPtyStrategy
+	}
+	if !isMinttyPty() {
+		return WindowsPtyDirect
+	}
+	if _, err := exec.LookPath("winpty"); err == nil {
+		return WindowsPtyWinpty
+	}
+	return WindowsPtyDetached
+}
+
+// EXTENDED how GhEditor.Prompt behaves when stdin is not a terminal, e.g. in
+// CI or when input is piped in (`echo "body" | gh issue create`)
+type NonInteractiveMode int
+
+const (
+	// NonInteractiveReadStdin reads the full body from stdin
+	NonInteractiveReadStdin NonInteractiveMode = iota
+	// NonInteractiveUseDefault returns GhEditor.Default without reading stdin
+	NonInteractiveUseDefault
+	// NonInteractiveError fails the prompt instead of guessing at input
+	NonInteractiveError
+)
+
+// EXTENDED functional option for configuring a GhEditor at construction time
+type Option func(*GhEditor)
+
+// EXTENDED pins the editor command, taking precedence over EditorResolver's
+// LookupEditor and the GH_EDITOR/GIT_EDITOR/VISUAL/EDITOR environment chain
+func WithEditorCommand(cmd string) Option {
+	return func(e *GhEditor) {
+		e.EditorCommand = cmd
+	}
+}
+
+// EXTENDED constructs a GhEditor, applying any Options on top of the defaults
+func NewGhEditor(editor *survey.Editor, opts ...Option) *GhEditor {
+	e := &GhEditor{Editor: editor}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+func (e *GhEditor) resolveEditor() (string, error) {
+	resolver := e.Resolver
+	if resolver == nil {
+		resolver = DefaultEditorResolver
+	}
+	return resolver.resolve(e)
+}
+
+// EXTENDED the display name shown in the prompt must account for editor
+// commands that carry arguments (e.g. "code --wait"), not just the bare path
+func editorDisplayName(cmd string) string {
+	args, err := shellquote.Split(cmd)
+	if err != nil || len(args) == 0 {
+		return cmd
+	}
+	return filepath.Base(args[0])
 }
 
 // EXTENDED to change prompt text
@@ -62,67 +214,113 @@ type EditorTemplateData struct {
 	Config     *survey.PromptConfig
 }
 
-// EXTENDED to augment prompt text and keypress handling
-func (e *GhEditor) prompt(initialValue string, config *survey.PromptConfig) (interface{}, error) {
-	err := e.Render(
-		EditorQuestionTemplate,
-		// EXTENDED to support printing editor in prompt
-		EditorTemplateData{
-			Editor:     *e.Editor,
-			EditorName: filepath.Base(editor),
-			Config:     config,
-		},
-	)
+// EXTENDED action the user picks when Validate rejects the edited text
+type editorRetryAction int
+
+const (
+	editorRetryEdit editorRetryAction = iota
+	editorRetryAccept
+	editorRetryCancel
+)
+
+func (e *GhEditor) commentChar() rune {
+	if e.CommentChar == 0 {
+		return '#'
+	}
+	return e.CommentChar
+}
+
+func (e *GhEditor) scissorLine() string {
+	if e.ScissorLine == "" {
+		return defaultCutLine
+	}
+	return e.ScissorLine
+}
+
+// EXTENDED to render MessageTemplate/TemplateData into a leading comment block
+// followed by the scissor line, ahead of the initial value shown in the editor
+func (e *GhEditor) renderMessageTemplate(initialValue string) (string, error) {
+	if e.MessageTemplate == "" {
+		return initialValue, nil
+	}
+
+	t, err := template.New("editorMessage").Parse(e.MessageTemplate)
 	if err != nil {
 		return "", err
 	}
 
-	// start reading runes from the standard in
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, e.TemplateData); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s\n%s\n%s", buf.String(), e.scissorLine(), initialValue), nil
+}
+
+// EXTENDED to discard the header added by renderMessageTemplate: ScissorLine and
+// everything above it are dropped, then any remaining leading lines whose first
+// non-whitespace rune is CommentChar are stripped. Text below ScissorLine is
+// kept, unlike `git commit --cleanup=scissors`, which drops it. Mirrors renderMessageTemplate's own guard:
+// a scissor line is only ever written when MessageTemplate was set, so the
+// leading-comment strip only runs once we've actually found and consumed one
+// — otherwise plain bodies that happen to start with e.g. a markdown "#
+// Heading" would get silently eaten.
+func (e *GhEditor) stripComments(text string) string {
+	lines := strings.Split(text, "\n")
+	scissorLine := e.scissorLine()
+
+	foundScissorLine := false
+	for i, l := range lines {
+		if l == scissorLine {
+			lines = lines[i+1:]
+			foundScissorLine = true
+			break
+		}
+	}
+
+	if !foundScissorLine {
+		return strings.Join(lines, "\n")
+	}
+
+	commentChar := string(e.commentChar())
+	start := 0
+	for start < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[start]), commentChar) {
+		start++
+	}
+
+	return strings.Join(lines[start:], "\n")
+}
+
+// EXTENDED asks the user how to proceed after Validate rejects the edited text
+func (e *GhEditor) promptForRetry(validationErr error) (editorRetryAction, error) {
+	stdio := e.Stdio()
+	fmt.Fprintf(stdio.Out, "Sorry, your reply was invalid: %s\n", validationErr)
+	fmt.Fprint(stdio.Out, "[(r) to re-edit, (a) to accept anyway, (c) to cancel] ")
+
 	rr := e.NewRuneReader()
 	rr.SetTermMode()
 	defer rr.RestoreTermMode()
 
-	cursor := e.NewCursor()
-	cursor.Hide()
-	defer cursor.Show()
-
 	for {
-		// EXTENDED to handle the e to edit / enter to skip behavior
 		r, _, err := rr.ReadRune()
 		if err != nil {
-			return "", err
-		}
-		if r == 'e' {
-			break
+			return editorRetryCancel, err
 		}
-		if r == '\r' || r == '\n' {
-			return "", nil
-		}
-		if r == terminal.KeyInterrupt {
-			return "", terminal.InterruptErr
-		}
-		if r == terminal.KeyEndTransmission {
-			break
-		}
-		if string(r) == config.HelpInput && e.Help != "" {
-			err = e.Render(
-				EditorQuestionTemplate,
-				EditorTemplateData{
-					// EXTENDED to support printing editor in prompt
-					Editor:     *e.Editor,
-					EditorName: filepath.Base(editor),
-					ShowHelp:   true,
-					Config:     config,
-				},
-			)
-			if err != nil {
-				return "", err
-			}
+
+		switch r {
+		case 'r', 'R':
+			return editorRetryEdit, nil
+		case 'a', 'A':
+			return editorRetryAccept, nil
+		case 'c', 'C', terminal.KeyInterrupt, terminal.KeyEndTransmission:
+			return editorRetryCancel, nil
 		}
-		continue
 	}
+}
 
-	// prepare the temp file
+// EXTENDED split out of prompt so the retry loop can relaunch the editor
+// against the previously entered content
+func (e *GhEditor) edit(initialValue, cmdString string) (string, error) {
 	pattern := e.FileName
 	if pattern == "" {
 		pattern = "survey*.txt"
@@ -155,24 +353,43 @@ func (e *GhEditor) prompt(initialValue string, config *survey.PromptConfig) (int
 
 	stdio := e.Stdio()
 
-	args, err := shellquote.Split(editor)
+	args, err := shellquote.Split(cmdString)
 	if err != nil {
 		return "", err
 	}
 	args = append(args, f.Name())
 
+	// EXTENDED a mintty/MSYS pty (e.g. Git Bash) can't drive a console editor
+	// through os/exec directly; wrap with winpty or fall back to a detached
+	// launch that waits for the editor process to exit
+	if runtime.GOOS == "windows" {
+		switch e.windowsPtyStrategy() {
+		case WindowsPtyWinpty:
+			args = append([]string{"winpty"}, args...)
+		case WindowsPtyDetached:
+			if err := e.runDetached(args); err != nil {
+				return "", err
+			}
+			return e.readEditedFile(f.Name())
+		}
+	}
+
 	// open the editor
 	cmd := exec.Command(args[0], args[1:]...)
 	cmd.Stdin = stdio.In
 	cmd.Stdout = stdio.Out
 	cmd.Stderr = stdio.Err
-	cursor.Show()
 	if err := cmd.Run(); err != nil {
 		return "", err
 	}
 
+	return e.readEditedFile(f.Name())
+}
+
+// EXTENDED shared by both the direct and detached launch paths
+func (e *GhEditor) readEditedFile(path string) (string, error) {
 	// raw is a BOM-unstripped UTF8 byte slice
-	raw, err := ioutil.ReadFile(f.Name())
+	raw, err := ioutil.ReadFile(path)
 	if err != nil {
 		return "", err
 	}
@@ -188,6 +405,170 @@ func (e *GhEditor) prompt(initialValue string, config *survey.PromptConfig) (int
 	return text, nil
 }
 
+// EXTENDED starts the editor detached (unattached to our stdio, since a
+// mintty pty without winpty can hang or garble output under cmd.Run()) and
+// waits for the process to actually exit. mtime polling was tried first but
+// is racy: a user who's still typing past the poll's stability window would
+// have their temp file removed out from under the still-running editor.
+func (e *GhEditor) runDetached(args []string) error {
+	cmd := exec.Command(args[0], args[1:]...)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	return <-done
+}
+
+// EXTENDED skips the rune-reader loop and editor launch when stdin isn't a
+// terminal, per NonInteractiveMode, instead of hanging on SetTermMode/ReadRune
+func (e *GhEditor) nonInteractivePrompt() (interface{}, error) {
+	switch e.NonInteractiveMode {
+	case NonInteractiveUseDefault:
+		return e.Default, nil
+	case NonInteractiveError:
+		return "", fmt.Errorf("%s requires a terminal to launch an editor; stdin is not interactive", e.Message)
+	default: // NonInteractiveReadStdin
+		b, err := ioutil.ReadAll(e.Stdio().In)
+		if err != nil {
+			return "", err
+		}
+
+		text := e.stripComments(string(b))
+
+		if e.Validate != nil {
+			if err := e.Validate(text); err != nil {
+				return "", err
+			}
+		}
+
+		return text, nil
+	}
+}
+
+// EXTENDED to augment prompt text and keypress handling
+func (e *GhEditor) prompt(initialValue string, config *survey.PromptConfig) (interface{}, error) {
+	if in, ok := e.Stdio().In.(terminal.FileReader); ok && !isatty.IsTerminal(in.Fd()) {
+		return e.nonInteractivePrompt()
+	}
+
+	cmdString, err := e.resolveEditor()
+	if err != nil {
+		return "", err
+	}
+	editorName := editorDisplayName(cmdString)
+
+	err = e.Render(
+		EditorQuestionTemplate,
+		// EXTENDED to support printing editor in prompt
+		EditorTemplateData{
+			Editor:     *e.Editor,
+			EditorName: editorName,
+			Config:     config,
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	// start reading runes from the standard in
+	rr := e.NewRuneReader()
+	rr.SetTermMode()
+	defer rr.RestoreTermMode()
+
+	cursor := e.NewCursor()
+	cursor.Hide()
+	defer cursor.Show()
+
+	for {
+		// EXTENDED to handle the e to edit / enter to skip behavior
+		r, _, err := rr.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		if r == 'e' {
+			break
+		}
+		if r == '\r' || r == '\n' {
+			return "", nil
+		}
+		if r == terminal.KeyInterrupt {
+			return "", terminal.InterruptErr
+		}
+		if r == terminal.KeyEndTransmission {
+			break
+		}
+		if string(r) == config.HelpInput && e.Help != "" {
+			err = e.Render(
+				EditorQuestionTemplate,
+				EditorTemplateData{
+					// EXTENDED to support printing editor in prompt
+					Editor:     *e.Editor,
+					EditorName: editorName,
+					ShowHelp:   true,
+					Config:     config,
+				},
+			)
+			if err != nil {
+				return "", err
+			}
+		}
+		continue
+	}
+
+	cursor.Show()
+
+	return e.editAndValidate(initialValue, cmdString)
+}
+
+// EXTENDED renders the message template, launches the editor, strips
+// comments, and runs the validate/re-edit/accept/cancel loop. Split out of
+// prompt so other prompt types (e.g. GhConfirmEditor) can jump straight to
+// the editor without re-entering prompt's own "(e) to launch" keypress gate.
+func (e *GhEditor) editAndValidate(initialValue, cmdString string) (interface{}, error) {
+	content, err := e.renderMessageTemplate(initialValue)
+	if err != nil {
+		return "", err
+	}
+
+	// EXTENDED to validate the edited text, offering a re-edit/accept/cancel
+	// choice on failure instead of silently returning invalid input
+	for {
+		raw, err := e.edit(content, cmdString)
+		if err != nil {
+			return "", err
+		}
+
+		text := e.stripComments(raw)
+
+		if e.Validate == nil {
+			return text, nil
+		}
+
+		if err := e.Validate(text); err == nil {
+			return text, nil
+		} else {
+			action, promptErr := e.promptForRetry(err)
+			if promptErr != nil {
+				return "", promptErr
+			}
+
+			switch action {
+			case editorRetryAccept:
+				return text, nil
+			case editorRetryCancel:
+				return "", terminal.InterruptErr
+			default: // editorRetryEdit
+				content = raw
+			}
+		}
+	}
+}
+
 // EXTENDED This is straight copypasta from survey to get our overridden prompt called.;
 func (e *GhEditor) Prompt(config *survey.PromptConfig) (interface{}, error) {
 	initialValue := ""
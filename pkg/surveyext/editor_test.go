@@ -0,0 +1,113 @@
+package surveyext
+
+import (
+	"os"
+	"testing"
+)
+
+func TestStripComments_NoMessageTemplate(t *testing.T) {
+	// EXTENDED regression test: without a MessageTemplate, no scissor line is
+	// ever rendered, so a body that happens to start with a markdown heading
+	// (or anything else beginning with '#') must come back untouched.
+	e := &GhEditor{}
+
+	input := "## Summary\nThis is my bug report.\nmore text"
+	got := e.stripComments(input)
+	if got != input {
+		t.Errorf("stripComments(%q) = %q, want unchanged input", input, got)
+	}
+}
+
+func TestStripComments_WithScissorLine(t *testing.T) {
+	e := &GhEditor{MessageTemplate: "# hints go here"}
+
+	header, err := e.renderMessageTemplate("my body")
+	if err != nil {
+		t.Fatalf("renderMessageTemplate: %v", err)
+	}
+
+	got := e.stripComments(header)
+	if got != "my body" {
+		t.Errorf("stripComments(%q) = %q, want %q", header, got, "my body")
+	}
+}
+
+func TestStripComments_CustomCommentCharAndScissorLine(t *testing.T) {
+	e := &GhEditor{CommentChar: ';', ScissorLine: "-- cut --"}
+
+	input := "; a hint\n; another hint\n-- cut --\n; hint below the cut\nbody line 1\nbody line 2"
+	got := e.stripComments(input)
+	want := "body line 1\nbody line 2"
+	if got != want {
+		t.Errorf("stripComments(%q) = %q, want %q", input, got, want)
+	}
+}
+
+func TestEditorResolverResolve(t *testing.T) {
+	for _, envVar := range []string{"GH_EDITOR", "GIT_EDITOR", "VISUAL", "EDITOR"} {
+		old, ok := os.LookupEnv(envVar)
+		defer func(envVar, old string, ok bool) {
+			if ok {
+				os.Setenv(envVar, old)
+			} else {
+				os.Unsetenv(envVar)
+			}
+		}(envVar, old, ok)
+		os.Unsetenv(envVar)
+	}
+
+	resolver := &EditorResolver{}
+
+	t.Run("explicit EditorCommand wins", func(t *testing.T) {
+		os.Setenv("GH_EDITOR", "should-not-be-used")
+		defer os.Unsetenv("GH_EDITOR")
+
+		e := &GhEditor{EditorCommand: "code --wait"}
+		got, err := resolver.resolve(e)
+		if err != nil {
+			t.Fatalf("resolve: %v", err)
+		}
+		if got != "code --wait" {
+			t.Errorf("resolve() = %q, want %q", got, "code --wait")
+		}
+	})
+
+	t.Run("LookupEditor takes precedence over environment", func(t *testing.T) {
+		os.Setenv("GH_EDITOR", "should-not-be-used")
+		defer os.Unsetenv("GH_EDITOR")
+
+		r := &EditorResolver{LookupEditor: func() (string, error) { return "vim", nil }}
+		got, err := r.resolve(&GhEditor{})
+		if err != nil {
+			t.Fatalf("resolve: %v", err)
+		}
+		if got != "vim" {
+			t.Errorf("resolve() = %q, want %q", got, "vim")
+		}
+	})
+
+	t.Run("GH_EDITOR takes precedence over GIT_EDITOR", func(t *testing.T) {
+		os.Setenv("GH_EDITOR", "gh-editor")
+		os.Setenv("GIT_EDITOR", "git-editor")
+		defer os.Unsetenv("GH_EDITOR")
+		defer os.Unsetenv("GIT_EDITOR")
+
+		got, err := resolver.resolve(&GhEditor{})
+		if err != nil {
+			t.Fatalf("resolve: %v", err)
+		}
+		if got != "gh-editor" {
+			t.Errorf("resolve() = %q, want %q", got, "gh-editor")
+		}
+	})
+
+	t.Run("falls back to a platform default", func(t *testing.T) {
+		got, err := resolver.resolve(&GhEditor{})
+		if err != nil {
+			t.Fatalf("resolve: %v", err)
+		}
+		if got == "" {
+			t.Errorf("resolve() = %q, want a non-empty default", got)
+		}
+	})
+}
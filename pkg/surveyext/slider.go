@@ -0,0 +1,142 @@
+package surveyext
+
+// This file adds a numeric-range prompt on top of survey, following the same
+// Render/NewRuneReader/template pattern established in editor.go.
+
+import (
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/terminal"
+)
+
+// GhSlider is a numeric-range prompt with left/right arrow key handling that
+// renders a text-mode bar, e.g. for "how many reviewers to request"
+type GhSlider struct {
+	survey.Renderer
+	Message string
+	Help    string
+	Min     int
+	Max     int
+	Step    int
+	Default int
+}
+
+// SliderQuestionTemplate is the text/template rendered when the prompt starts,
+// after each left/right arrow keypress, and by Cleanup
+var SliderQuestionTemplate = `
+{{- if .ShowHelp }}{{- color .Config.Icons.Help.Format }}{{ .Config.Icons.Help.Text }} {{ .Help }}{{color "reset"}}{{"\n"}}{{end}}
+{{- color .Config.Icons.Question.Format }}{{ .Config.Icons.Question.Text }} {{color "reset"}}
+{{- color "default+hb"}}{{ .Message }} {{color "reset"}}
+{{- color "cyan"}}[{{ .Bar }}] {{ .Value }}{{color "reset"}}
+{{- if not .ShowAnswer}} {{color "cyan"}}(←/→ to adjust, enter to confirm){{color "reset"}}{{end}}`
+
+// SliderTemplateData is the data passed to SliderQuestionTemplate
+type SliderTemplateData struct {
+	GhSlider
+	Bar        string
+	Value      int
+	ShowAnswer bool
+	ShowHelp   bool
+	Config     *survey.PromptConfig
+}
+
+const sliderBarWidth = 20
+
+func (s *GhSlider) bar(value int) string {
+	span := s.Max - s.Min
+	if span <= 0 {
+		span = 1
+	}
+
+	filled := ((value - s.Min) * sliderBarWidth) / span
+	if filled < 0 {
+		filled = 0
+	}
+	if filled > sliderBarWidth {
+		filled = sliderBarWidth
+	}
+
+	return strings.Repeat("=", filled) + strings.Repeat("-", sliderBarWidth-filled)
+}
+
+func (s *GhSlider) render(value int, showAnswer bool, config *survey.PromptConfig) error {
+	return s.Render(
+		SliderQuestionTemplate,
+		SliderTemplateData{
+			GhSlider:   *s,
+			Bar:        s.bar(value),
+			Value:      value,
+			ShowAnswer: showAnswer,
+			Config:     config,
+		},
+	)
+}
+
+// Prompt implements survey.Prompt
+func (s *GhSlider) Prompt(config *survey.PromptConfig) (interface{}, error) {
+	step := s.Step
+	if step <= 0 {
+		step = 1
+	}
+
+	value := s.Default
+	if value < s.Min {
+		value = s.Min
+	}
+	if value > s.Max {
+		value = s.Max
+	}
+
+	cursor := s.NewCursor()
+	cursor.Hide()
+	defer cursor.Show()
+
+	if err := s.render(value, false, config); err != nil {
+		return "", err
+	}
+
+	rr := s.NewRuneReader()
+	rr.SetTermMode()
+	defer rr.RestoreTermMode()
+
+	for {
+		r, _, err := rr.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		switch r {
+		case terminal.KeyArrowLeft:
+			value -= step
+		case terminal.KeyArrowRight:
+			value += step
+		case '\r', '\n', terminal.KeyEndTransmission:
+			return value, nil
+		case terminal.KeyInterrupt:
+			return "", terminal.InterruptErr
+		default:
+			continue
+		}
+
+		if value < s.Min {
+			value = s.Min
+		}
+		if value > s.Max {
+			value = s.Max
+		}
+
+		if err := s.render(value, false, config); err != nil {
+			return "", err
+		}
+	}
+}
+
+// Cleanup implements survey.Prompt
+func (s *GhSlider) Cleanup(config *survey.PromptConfig, val interface{}) error {
+	value, ok := val.(int)
+	if !ok {
+		value = s.Default
+	}
+	return s.render(value, true, config)
+}
@@ -0,0 +1,44 @@
+package surveyext
+
+import "testing"
+
+func TestGhSliderBar(t *testing.T) {
+	s := &GhSlider{Min: 0, Max: 10}
+
+	tests := []struct {
+		value    int
+		wantFull bool
+		wantZero bool
+	}{
+		{value: 0, wantZero: true},
+		{value: 10, wantFull: true},
+		{value: 5},
+	}
+
+	for _, tt := range tests {
+		bar := s.bar(tt.value)
+		if len(bar) != sliderBarWidth {
+			t.Errorf("bar(%d) has length %d, want %d", tt.value, len(bar), sliderBarWidth)
+		}
+		if tt.wantZero && bar[0] == '=' {
+			t.Errorf("bar(%d) = %q, want no filled segment", tt.value, bar)
+		}
+		if tt.wantFull && bar[sliderBarWidth-1] != '=' {
+			t.Errorf("bar(%d) = %q, want a fully filled bar", tt.value, bar)
+		}
+	}
+}
+
+func TestGhSliderBarClampsOutOfRangeValues(t *testing.T) {
+	s := &GhSlider{Min: 0, Max: 10}
+
+	belowMin := s.bar(-5)
+	aboveMax := s.bar(100)
+
+	if belowMin != s.bar(0) {
+		t.Errorf("bar(-5) = %q, want same as bar(0) = %q", belowMin, s.bar(0))
+	}
+	if aboveMax != s.bar(10) {
+		t.Errorf("bar(100) = %q, want same as bar(10) = %q", aboveMax, s.bar(10))
+	}
+}